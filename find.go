@@ -7,7 +7,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -16,6 +20,391 @@ type Finder interface {
 	Find() ([]string, error)
 }
 
+// A semver is a lenient parse of a semantic version tag: a leading 'v' is
+// stripped, missing minor/patch components default to 0, and any build
+// metadata ('+...') or pre-release suffix ('-rc1') is tolerated.
+type semver struct {
+	major, minor, patch int
+	pre                 string
+}
+
+// parseSemver parses tag as a semver, returning false if it cannot be
+// interpreted as one.
+func parseSemver(tag string) (semver, bool) {
+	tag = strings.TrimPrefix(tag, "v")
+
+	if i := strings.IndexByte(tag, '+'); i >= 0 {
+		tag = tag[:i]
+	}
+
+	var pre string
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		pre = tag[i+1:]
+		tag = tag[:i]
+	}
+
+	parts := strings.SplitN(tag, ".", 3)
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		nums[i] = n
+	}
+
+	return semver{major: nums[0], minor: nums[1], patch: nums[2], pre: pre}, true
+}
+
+// compareSemver returns a negative number if a < b, 0 if a == b, and a
+// positive number if a > b. A version without a pre-release outranks the
+// same version with one (1.0.0 > 1.0.0-rc1).
+func compareSemver(a, b semver) int {
+	if a.major != b.major {
+		return a.major - b.major
+	}
+	if a.minor != b.minor {
+		return a.minor - b.minor
+	}
+	if a.patch != b.patch {
+		return a.patch - b.patch
+	}
+	switch {
+	case a.pre == b.pre:
+		return 0
+	case a.pre == "":
+		return 1
+	case b.pre == "":
+		return -1
+	case a.pre < b.pre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// A semverConstraint is a single '<op><version>' term, eg. '>=1.4.0' or
+// '^1.2'. The '=' op means exact match; a term without an op is never a
+// constraint (see parseSemverConstraint).
+type semverConstraint struct {
+	op  string
+	ver semver
+}
+
+func (c semverConstraint) match(v semver) bool {
+	cmp := compareSemver(v, c.ver)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "^":
+		// compatible changes: same major version, at least c.ver
+		return cmp >= 0 && v.major == c.ver.major
+	case "~":
+		// compatible patch releases: same major.minor, at least c.ver
+		return cmp >= 0 && v.major == c.ver.major && v.minor == c.ver.minor
+	}
+	return false
+}
+
+// semverConstraints is a set of constraints that must all match (as in
+// '>=1.4.0 <2').
+type semverConstraints []semverConstraint
+
+func (cs semverConstraints) match(v semver) bool {
+	for _, c := range cs {
+		if !c.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// semverConstraintOps lists the recognised prefix operators, longest first so
+// that '>=' is matched before '>'.
+var semverConstraintOps = []string{">=", "<=", ">", "<", "^", "~", "="}
+
+// parseSemverConstraint parses a single term such as '^1.2' or '>=1.4.0'.
+// A term with no recognised operator prefix is not a constraint: it's left
+// for the caller to substring-match instead, matching eget's long-standing
+// --tag behaviour for plain (non-constraint) tags.
+func parseSemverConstraint(term string) (semverConstraint, bool) {
+	for _, op := range semverConstraintOps {
+		if rest, ok := strings.CutPrefix(term, op); ok {
+			ver, ok := parseSemver(rest)
+			if !ok {
+				return semverConstraint{}, false
+			}
+			return semverConstraint{op: op, ver: ver}, true
+		}
+	}
+
+	return semverConstraint{}, false
+}
+
+// parseSemverConstraints parses a space-separated constraint expression such
+// as '^1.2' or '>=1.4.0 <2'. It returns false if tag isn't a valid
+// constraint expression, in which case callers should fall back to substring
+// matching.
+func parseSemverConstraints(tag string) (semverConstraints, bool) {
+	terms := strings.Fields(tag)
+	if len(terms) == 0 {
+		return nil, false
+	}
+
+	constraints := make(semverConstraints, 0, len(terms))
+	for _, term := range terms {
+		c, ok := parseSemverConstraint(term)
+		if !ok {
+			return nil, false
+		}
+		constraints = append(constraints, c)
+	}
+
+	return constraints, true
+}
+
+// A ReleaseFilter selects which kind of releases a Finder is allowed to
+// return. StableOnly takes precedence over the other two fields: if set,
+// neither drafts nor prereleases are ever matched.
+type ReleaseFilter struct {
+	IncludeDraft      bool
+	IncludePrerelease bool
+	StableOnly        bool
+}
+
+// Match reports whether a release with the given prerelease/draft flags
+// satisfies the filter.
+func (rf ReleaseFilter) Match(prerelease, draft bool) bool {
+	if rf.StableOnly {
+		return !prerelease && !draft
+	}
+	if draft && !rf.IncludeDraft {
+		return false
+	}
+	if prerelease && !rf.IncludePrerelease {
+		return false
+	}
+	return true
+}
+
+// listable reports whether this filter can match releases that a host's
+// 'latest' endpoint would never return (ie. drafts or prereleases), meaning
+// the release list must be walked instead.
+func (rf ReleaseFilter) listable() bool {
+	return !rf.StableOnly && (rf.IncludeDraft || rf.IncludePrerelease)
+}
+
+// A TokenSource resolves the API token to send for requests against a given
+// host. A zero-value TokenSource falls back to GITHUB_TOKEN for github.com
+// and GITLAB_TOKEN for gitlab.com. GITEA_TOKEN has no fixed host and is not
+// read here; the Gitea finders fall back to it through fetchJSON.
+type TokenSource struct {
+	// Hosts maps a host name (eg. "git.example.com") to the token to send
+	// for requests against that host. Used to configure tokens for hosts
+	// with no well-known environment variable, such as GitHub Enterprise or
+	// a self-hosted Gitlab/Gitea instance.
+	Hosts map[string]string
+}
+
+// DefaultTokenSource is the TokenSource used by Finders that are not given
+// one explicitly.
+var DefaultTokenSource = &TokenSource{}
+
+// Token returns the token to use for requests against host, or "" if none is
+// configured. Hosts without a well-known environment variable (eg. GitHub
+// Enterprise, or a self-hosted Gitlab/Gitea instance) must be configured
+// explicitly via Hosts: unknown hosts never receive another host's token.
+func (ts *TokenSource) Token(host string) string {
+	if ts != nil {
+		if token, ok := ts.Hosts[host]; ok && token != "" {
+			return token
+		}
+	}
+
+	switch host {
+	case "github.com", "api.github.com":
+		return os.Getenv("GITHUB_TOKEN")
+	case "gitlab.com":
+		return os.Getenv("GITLAB_TOKEN")
+	default:
+		return ""
+	}
+}
+
+// A Cache stores the last seen ETag/Last-Modified and body for a release API
+// response on disk, so that repeated lookups can be satisfied with a
+// conditional request (or no request at all, within TTL) instead of
+// spending rate limit budget on data we already have.
+type Cache struct {
+	TTL      time.Duration // how long a cached entry is used without revalidating; 0 disables this
+	Disabled bool          // bypasses the cache entirely: nothing is read from or written to disk
+}
+
+// DefaultCache is the Cache used by Finders that are not given one
+// explicitly. It revalidates on every lookup (TTL == 0) but still avoids
+// spending rate limit via conditional requests.
+var DefaultCache = &Cache{}
+
+type cacheEntry struct {
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	FetchedAt    time.Time       `json:"fetched_at"`
+	Body         json.RawMessage `json:"body"`
+}
+
+// cachePath returns the on-disk location for the cached response of the
+// given host/repo/tag combination, under $XDG_CACHE_HOME/eget/releases.
+func cachePath(host, repo, tag string) (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".cache")
+	}
+
+	tag = strings.NewReplacer("/", "_", "?", "_").Replace(tag)
+	if tag == "" {
+		tag = "latest"
+	}
+
+	return filepath.Join(base, "eget", "releases", host, repo, tag+".json"), nil
+}
+
+func (c *Cache) load(path string) (cacheEntry, bool) {
+	if c == nil || c.Disabled || path == "" {
+		return cacheEntry{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *Cache) save(path string, entry cacheEntry) {
+	if c == nil || c.Disabled || path == "" {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if os.MkdirAll(filepath.Dir(path), 0o755) != nil {
+		return
+	}
+	os.WriteFile(path, data, 0o644)
+}
+
+func (c *Cache) fresh(entry cacheEntry, ok bool) bool {
+	return ok && c != nil && !c.Disabled && c.TTL > 0 && time.Since(entry.FetchedAt) < c.TTL
+}
+
+// fetchJSON performs a cache-aware, authenticated GET request for a release
+// API resource and returns its raw json body. host selects the token to
+// attach (see TokenSource) and accept pins the Accept header the forge's API
+// expects. fallbackEnv, if non-empty, is an environment variable consulted
+// for a token only when host has none configured in tokens or via a
+// well-known variable; callers use this to let eg. a self-hosted
+// Gitea/Forgejo host fall back to GITEA_TOKEN without that token leaking to
+// an unrelated host. repo and tag identify the resource for caching
+// purposes; tag may be a literal tag, "latest", or a synthetic key such as
+// "page-2". newErr builds the finder-specific error
+// (GithubError/GitlabError/GiteaError) for any response other than 200 OK or
+// a 304 Not Modified answering a request made from a cache entry.
+func fetchJSON(reqUrl, host, accept, repo, tag, fallbackEnv string, tokens *TokenSource, cache *Cache, newErr func(resp *http.Response, body []byte, url string) error) ([]byte, error) {
+	if cache == nil {
+		cache = DefaultCache
+	}
+
+	path, pathErr := cachePath(host, repo, tag)
+	entry, cached := cache.load(path)
+
+	if cache.fresh(entry, cached) {
+		return entry.Body, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "eget")
+	req.Header.Set("Accept", accept)
+	if tokens == nil {
+		tokens = DefaultTokenSource
+	}
+	token := tokens.Token(host)
+	if token == "" && fallbackEnv != "" {
+		token = os.Getenv(fallbackEnv)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if cached {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached {
+		// the cached body is still current; just refresh its timestamp so
+		// the TTL window restarts without spending any rate limit.
+		entry.FetchedAt = time.Now()
+		if pathErr == nil {
+			cache.save(path, entry)
+		}
+		return entry.Body, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newErr(resp, body, reqUrl)
+	}
+
+	if pathErr == nil {
+		cache.save(path, cacheEntry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    time.Now(),
+			Body:         json.RawMessage(body),
+		})
+	}
+
+	return body, nil
+}
+
 // A GithubRelease matches the Assets portion of Github's release API json.
 type GithubRelease struct {
 	Assets []struct {
@@ -23,6 +412,7 @@ type GithubRelease struct {
 	} `json:"assets"`
 
 	Prerelease bool      `json:"prerelease"`
+	Draft      bool      `json:"draft"`
 	Tag        string    `json:"tag_name"`
 	CreatedAt  time.Time `json:"created_at"`
 }
@@ -45,6 +435,12 @@ type GithubError struct {
 	Status string
 	Body   []byte
 	Url    string
+
+	// RateLimitLimit, RateLimitRemaining and RateLimitReset are populated
+	// from the response's X-RateLimit-* headers, if present.
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     time.Time
 }
 
 type githubErrResponse struct {
@@ -57,11 +453,37 @@ func (ge *GithubError) Error() string {
 	json.Unmarshal(ge.Body, &msg)
 
 	if ge.Code == http.StatusForbidden {
+		if ge.RateLimitRemaining == 0 && !ge.RateLimitReset.IsZero() {
+			return fmt.Sprintf("%s: %s: rate limit exceeded, resets at %s", ge.Status, msg.Message, ge.RateLimitReset.Format(time.RFC1123))
+		}
 		return fmt.Sprintf("%s: %s: %s", ge.Status, msg.Message, msg.Doc)
 	}
 	return fmt.Sprintf("%s (URL: %s)", ge.Status, ge.Url)
 }
 
+// newGithubError builds a GithubError from a non-2xx response, capturing any
+// rate-limit information the response carries.
+func newGithubError(resp *http.Response, body []byte, url string) *GithubError {
+	ge := &GithubError{
+		Status: resp.Status,
+		Code:   resp.StatusCode,
+		Body:   body,
+		Url:    url,
+	}
+
+	if limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		ge.RateLimitLimit = limit
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		ge.RateLimitRemaining = remaining
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		ge.RateLimitReset = time.Unix(reset, 0)
+	}
+
+	return ge
+}
+
 type GitlabError struct {
 	Code   int
 	Status string
@@ -73,19 +495,33 @@ func (ge *GitlabError) Error() string {
 	return fmt.Sprintf("%s (URL: %s)", ge.Status, ge.Url)
 }
 
+func newGitlabError(resp *http.Response, body []byte, url string) *GitlabError {
+	return &GitlabError{
+		Status: resp.Status,
+		Code:   resp.StatusCode,
+		Body:   body,
+		Url:    url,
+	}
+}
+
 // A GithubAssetFinder finds assets for the given Repo at the given tag. Tags
 // must be given as 'tag/<tag>'. Use 'latest' to get the latest release.
 type GithubAssetFinder struct {
-	Repo       string
-	Tag        string
-	Prerelease bool
-	MinTime    time.Time // release must be after MinTime to be found
+	Repo    string
+	Tag     string
+	Filter  ReleaseFilter
+	MinTime time.Time // release must be after MinTime to be found
+	Tokens  *TokenSource
+	Cache   *Cache
 }
 
+// githubAcceptHeader pins the API version restic and hub both rely on.
+const githubAcceptHeader = "application/vnd.github.v3+json"
+
 var ErrNoUpgrade = errors.New("requested release is not more recent than current version")
 
 func (f *GithubAssetFinder) Find() ([]string, error) {
-	if f.Prerelease && f.Tag == "latest" {
+	if f.Filter.listable() && f.Tag == "latest" {
 		tag, err := f.getLatestTag()
 		if err != nil {
 			return nil, err
@@ -95,32 +531,13 @@ func (f *GithubAssetFinder) Find() ([]string, error) {
 
 	// query github's API for this repo/tag pair.
 	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/%s", f.Repo, f.Tag)
-	resp, err := Get(url)
+	body, err := fetchJSON(url, "api.github.com", githubAcceptHeader, f.Repo, f.Tag, "", f.Tokens, f.Cache,
+		func(resp *http.Response, body []byte, url string) error { return newGithubError(resp, body, url) })
 	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
-		if strings.HasPrefix(f.Tag, "tags/") && resp.StatusCode == http.StatusNotFound {
+		var ge *GithubError
+		if strings.HasPrefix(f.Tag, "tags/") && errors.As(err, &ge) && ge.Code == http.StatusNotFound {
 			return f.FindMatch()
 		}
-		return nil, &GithubError{
-			Status: resp.Status,
-			Code:   resp.StatusCode,
-			Body:   body,
-			Url:    url,
-		}
-	}
-
-	// read and unmarshal the resulting json
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
 		return nil, err
 	}
 
@@ -145,31 +562,16 @@ func (f *GithubAssetFinder) Find() ([]string, error) {
 
 func (f *GithubAssetFinder) FindMatch() ([]string, error) {
 	tag := f.Tag[len("tags/"):]
+	constraints, semverMode := parseSemverConstraints(tag)
+
+	var best GithubRelease
+	var bestVer semver
+	haveBest := false
 
 	for page := 1; ; page++ {
 		url := fmt.Sprintf("https://api.github.com/repos/%s/releases?page=%d", f.Repo, page)
-		resp, err := Get(url)
-		if err != nil {
-			return nil, err
-		}
-
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, err
-			}
-			return nil, &GithubError{
-				Status: resp.Status,
-				Code:   resp.StatusCode,
-				Body:   body,
-				Url:    url,
-			}
-		}
-
-		// read and unmarshal the resulting json
-		body, err := io.ReadAll(resp.Body)
+		body, err := fetchJSON(url, "api.github.com", githubAcceptHeader, f.Repo, fmt.Sprintf("page-%d", page), "", f.Tokens, f.Cache,
+			func(resp *http.Response, body []byte, url string) error { return newGithubError(resp, body, url) })
 		if err != nil {
 			return nil, err
 		}
@@ -181,10 +583,19 @@ func (f *GithubAssetFinder) FindMatch() ([]string, error) {
 		}
 
 		for _, r := range releases {
-			if !f.Prerelease && r.Prerelease {
+			if !f.Filter.Match(r.Prerelease, r.Draft) || r.CreatedAt.Before(f.MinTime) {
+				continue
+			}
+
+			if semverMode {
+				v, ok := parseSemver(r.Tag)
+				if ok && constraints.match(v) && (!haveBest || compareSemver(v, bestVer) > 0) {
+					best, bestVer, haveBest = r, v, true
+				}
 				continue
 			}
-			if strings.Contains(r.Tag, tag) && !r.CreatedAt.Before(f.MinTime) {
+
+			if strings.Contains(r.Tag, tag) {
 				// we have a winner
 				assets := make([]string, 0, len(r.Assets))
 				for _, a := range r.Assets {
@@ -199,46 +610,60 @@ func (f *GithubAssetFinder) FindMatch() ([]string, error) {
 		}
 	}
 
+	if haveBest {
+		assets := make([]string, 0, len(best.Assets))
+		for _, a := range best.Assets {
+			assets = append(assets, a.DownloadURL)
+		}
+		return assets, nil
+	}
+
 	return nil, fmt.Errorf("no matching tag for '%s'", tag)
 }
 
-// finds the latest pre-release and returns the tag
+// finds the latest release matching the finder's filter and returns its tag
 func (f *GithubAssetFinder) getLatestTag() (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases", f.Repo)
-	resp, err := Get(url)
-	if err != nil {
-		return "", fmt.Errorf("pre-release finder: %w", err)
-	}
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/releases?page=%d", f.Repo, page)
+		body, err := fetchJSON(url, "api.github.com", githubAcceptHeader, f.Repo, fmt.Sprintf("page-%d", page), "", f.Tokens, f.Cache,
+			func(resp *http.Response, body []byte, url string) error { return newGithubError(resp, body, url) })
+		if err != nil {
+			return "", fmt.Errorf("pre-release finder: %w", err)
+		}
 
-	var releases []GithubRelease
+		var releases []GithubRelease
+		err = json.Unmarshal(body, &releases)
+		if err != nil {
+			return "", fmt.Errorf("pre-release finder: %w", err)
+		}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("pre-release finder: %w", err)
-	}
-	err = json.Unmarshal(body, &releases)
-	if err != nil {
-		return "", fmt.Errorf("pre-release finder: %w", err)
-	}
+		for _, r := range releases {
+			if f.Filter.Match(r.Prerelease, r.Draft) {
+				return r.Tag, nil
+			}
+		}
 
-	if len(releases) <= 0 {
-		return "", fmt.Errorf("no releases found")
+		if len(releases) < 30 {
+			break
+		}
 	}
 
-	return releases[0].Tag, nil
+	return "", fmt.Errorf("no releases found")
 }
 
 // A GitlabAssetFinder finds assets for the given Repo at the given tag. Tags
 // must be given as 'tag/<tag>'. Use 'latest' to get the latest release.
 type GitlabAssetFinder struct {
-	Repo       string
-	Tag        string
-	Prerelease bool
-	MinTime    time.Time // release must be after MinTime to be found
+	Repo    string
+	Tag     string
+	Filter  ReleaseFilter
+	MinTime time.Time // release must be after MinTime to be found
+	Tokens  *TokenSource
+	Cache   *Cache
 }
 
 func (f *GitlabAssetFinder) Find() ([]string, error) {
-	if f.Prerelease && f.Tag == "latest" {
+	if f.Filter.listable() && f.Tag == "latest" {
 		tag, err := f.getLatestTag()
 		if err != nil {
 			return nil, err
@@ -257,36 +682,132 @@ func (f *GitlabAssetFinder) Find() ([]string, error) {
 		}
 	}
 
-	resp, err := Get(reqUrl)
+	body, err := fetchJSON(reqUrl, "gitlab.com", "application/json", f.Repo, f.Tag, "", f.Tokens, f.Cache,
+		func(resp *http.Response, body []byte, url string) error { return newGitlabError(resp, body, url) })
 	if err != nil {
+		var ge *GitlabError
+		if strings.HasPrefix(f.Tag, "tags/") && errors.As(err, &ge) && ge.Code == http.StatusNotFound {
+			return nil, fmt.Errorf("no matching tag for '%s'", f.Tag[len("tags/"):])
+		}
 		return nil, err
 	}
 
-	defer resp.Body.Close()
+	var release GitlabRelease
+	err = json.Unmarshal(body, &release)
+	if err != nil {
+		return nil, err
+	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
+	if release.CreatedAt.Before(f.MinTime) {
+		return nil, ErrNoUpgrade
+	}
+
+	// accumulate all assets from the json into a slice
+	assets := make([]string, 0, len(release.Assets.Links))
+	for _, a := range release.Assets.Links {
+		assets = append(assets, a.DownloadURL)
+	}
+
+	return assets, nil
+}
+
+// finds the latest release matching the finder's filter and returns its tag
+func (f *GitlabAssetFinder) getLatestTag() (string, error) {
+	for page := 1; ; page++ {
+		reqUrl := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/releases?page=%d", url.QueryEscape(f.Repo), page)
+		body, err := fetchJSON(reqUrl, "gitlab.com", "application/json", f.Repo, fmt.Sprintf("page-%d", page), "", f.Tokens, f.Cache,
+			func(resp *http.Response, body []byte, url string) error { return newGitlabError(resp, body, url) })
 		if err != nil {
-			return nil, err
+			return "", fmt.Errorf("pre-release finder: %w", err)
 		}
-		if strings.HasPrefix(f.Tag, "tags/") && resp.StatusCode == http.StatusNotFound {
-			return nil, fmt.Errorf("no matching tag for '%s'", f.Tag[len("tags/"):])
+
+		var releases []GitlabRelease
+		err = json.Unmarshal(body, &releases)
+		if err != nil {
+			return "", fmt.Errorf("pre-release finder: %w", err)
+		}
+
+		for _, r := range releases {
+			if f.Filter.Match(r.UpcomingRelease, false) {
+				return r.Tag, nil
+			}
 		}
-		return nil, &GitlabError{
-			Status: resp.Status,
-			Code:   resp.StatusCode,
-			Body:   body,
-			Url:    reqUrl,
+
+		if len(releases) < 20 {
+			break
 		}
 	}
 
-	// read and unmarshal the resulting json
-	body, err := io.ReadAll(resp.Body)
+	return "", fmt.Errorf("no releases found")
+}
+
+// A GiteaRelease matches the Assets portion of Gitea/Forgejo's release API json.
+type GiteaRelease struct {
+	Assets []struct {
+		DownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+
+	Prerelease bool      `json:"prerelease"`
+	Draft      bool      `json:"draft"`
+	Tag        string    `json:"tag_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type GiteaError struct {
+	Code   int
+	Status string
+	Body   []byte
+	Url    string
+}
+
+func (ge *GiteaError) Error() string {
+	return fmt.Sprintf("%s (URL: %s)", ge.Status, ge.Url)
+}
+
+func newGiteaError(resp *http.Response, body []byte, url string) *GiteaError {
+	return &GiteaError{
+		Status: resp.Status,
+		Code:   resp.StatusCode,
+		Body:   body,
+		Url:    url,
+	}
+}
+
+// A GiteaAssetFinder finds assets for the given Repo at the given tag, on the
+// given Gitea/Forgejo Host. Tags must be given as 'tags/<tag>'. Use 'latest'
+// to get the latest release.
+type GiteaAssetFinder struct {
+	Host    string
+	Repo    string
+	Tag     string
+	Filter  ReleaseFilter
+	MinTime time.Time // release must be after MinTime to be found
+	Tokens  *TokenSource
+	Cache   *Cache
+}
+
+func (f *GiteaAssetFinder) Find() ([]string, error) {
+	if f.Filter.listable() && f.Tag == "latest" {
+		tag, err := f.getLatestTag()
+		if err != nil {
+			return nil, err
+		}
+		f.Tag = fmt.Sprintf("tags/%s", tag)
+	}
+
+	// query the gitea/forgejo instance's API for this repo/tag pair.
+	url := fmt.Sprintf("https://%s/api/v1/repos/%s/releases/%s", f.Host, f.Repo, f.Tag)
+	body, err := fetchJSON(url, f.Host, "application/json", f.Repo, f.Tag, "GITEA_TOKEN", f.Tokens, f.Cache,
+		func(resp *http.Response, body []byte, url string) error { return newGiteaError(resp, body, url) })
 	if err != nil {
+		var ge *GiteaError
+		if strings.HasPrefix(f.Tag, "tags/") && errors.As(err, &ge) && ge.Code == http.StatusNotFound {
+			return f.FindMatch()
+		}
 		return nil, err
 	}
 
-	var release GitlabRelease
+	var release GiteaRelease
 	err = json.Unmarshal(body, &release)
 	if err != nil {
 		return nil, err
@@ -297,38 +818,103 @@ func (f *GitlabAssetFinder) Find() ([]string, error) {
 	}
 
 	// accumulate all assets from the json into a slice
-	assets := make([]string, 0, len(release.Assets.Links))
-	for _, a := range release.Assets.Links {
+	assets := make([]string, 0, len(release.Assets))
+	for _, a := range release.Assets {
 		assets = append(assets, a.DownloadURL)
 	}
 
 	return assets, nil
 }
 
-// finds the latest pre-release and returns the tag
-func (f *GitlabAssetFinder) getLatestTag() (string, error) {
-	reqUrl := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/releases", url.QueryEscape(f.Repo))
-	resp, err := Get(reqUrl)
-	if err != nil {
-		return "", fmt.Errorf("pre-release finder: %w", err)
-	}
+func (f *GiteaAssetFinder) FindMatch() ([]string, error) {
+	tag := f.Tag[len("tags/"):]
+	constraints, semverMode := parseSemverConstraints(tag)
 
-	var releases []GitlabRelease
+	var best GiteaRelease
+	var bestVer semver
+	haveBest := false
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("pre-release finder: %w", err)
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("https://%s/api/v1/repos/%s/releases?page=%d", f.Host, f.Repo, page)
+		body, err := fetchJSON(url, f.Host, "application/json", f.Repo, fmt.Sprintf("page-%d", page), "GITEA_TOKEN", f.Tokens, f.Cache,
+			func(resp *http.Response, body []byte, url string) error { return newGiteaError(resp, body, url) })
+		if err != nil {
+			return nil, err
+		}
+
+		var releases []GiteaRelease
+		err = json.Unmarshal(body, &releases)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range releases {
+			if !f.Filter.Match(r.Prerelease, r.Draft) || r.CreatedAt.Before(f.MinTime) {
+				continue
+			}
+
+			if semverMode {
+				v, ok := parseSemver(r.Tag)
+				if ok && constraints.match(v) && (!haveBest || compareSemver(v, bestVer) > 0) {
+					best, bestVer, haveBest = r, v, true
+				}
+				continue
+			}
+
+			if strings.Contains(r.Tag, tag) {
+				// we have a winner
+				assets := make([]string, 0, len(r.Assets))
+				for _, a := range r.Assets {
+					assets = append(assets, a.DownloadURL)
+				}
+				return assets, nil
+			}
+		}
+
+		if len(releases) == 0 {
+			break
+		}
 	}
-	err = json.Unmarshal(body, &releases)
-	if err != nil {
-		return "", fmt.Errorf("pre-release finder: %w", err)
+
+	if haveBest {
+		assets := make([]string, 0, len(best.Assets))
+		for _, a := range best.Assets {
+			assets = append(assets, a.DownloadURL)
+		}
+		return assets, nil
 	}
 
-	if len(releases) <= 0 {
-		return "", fmt.Errorf("no releases found")
+	return nil, fmt.Errorf("no matching tag for '%s'", tag)
+}
+
+// finds the latest release matching the finder's filter and returns its tag
+func (f *GiteaAssetFinder) getLatestTag() (string, error) {
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("https://%s/api/v1/repos/%s/releases?page=%d", f.Host, f.Repo, page)
+		body, err := fetchJSON(url, f.Host, "application/json", f.Repo, fmt.Sprintf("page-%d", page), "GITEA_TOKEN", f.Tokens, f.Cache,
+			func(resp *http.Response, body []byte, url string) error { return newGiteaError(resp, body, url) })
+		if err != nil {
+			return "", fmt.Errorf("pre-release finder: %w", err)
+		}
+
+		var releases []GiteaRelease
+		err = json.Unmarshal(body, &releases)
+		if err != nil {
+			return "", fmt.Errorf("pre-release finder: %w", err)
+		}
+
+		for _, r := range releases {
+			if f.Filter.Match(r.Prerelease, r.Draft) {
+				return r.Tag, nil
+			}
+		}
+
+		if len(releases) == 0 {
+			break
+		}
 	}
 
-	return releases[0].Tag, nil
+	return "", fmt.Errorf("no releases found")
 }
 
 // A DirectAssetFinder returns the embedded URL directly as the only asset.
@@ -359,3 +945,59 @@ type GitlabSourceFinder struct {
 func (f *GitlabSourceFinder) Find() ([]string, error) {
 	return []string{fmt.Sprintf("https://gitlab.com/%s/-/archive/%s/%s.tar.gz", f.Repo, f.Tag, f.Tool)}, nil
 }
+
+type GiteaSourceFinder struct {
+	Tool string
+	Host string
+	Repo string
+	Tag  string
+}
+
+func (f *GiteaSourceFinder) Find() ([]string, error) {
+	return []string{fmt.Sprintf("https://%s/%s/archive/%s.tar.gz", f.Host, f.Repo, f.Tag)}, nil
+}
+
+// A FindResult is the outcome of resolving a single Finder passed to
+// FindAll, at the same index as the Finder in its input slice.
+type FindResult struct {
+	Assets []string
+	Err    error
+}
+
+// FindAll resolves many Finders concurrently, using up to concurrency
+// workers, and returns one FindResult per Finder in finders, in the same
+// order. A Finder that fails does not abort the batch: its error (eg. a
+// GithubError/GitlabError/GiteaError, or ErrNoUpgrade) is recorded in the
+// corresponding FindResult instead.
+func FindAll(finders []Finder, concurrency int) ([]FindResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(finders) {
+		concurrency = len(finders)
+	}
+
+	results := make([]FindResult, len(finders))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				assets, err := finders[idx].Find()
+				results[idx] = FindResult{Assets: assets, Err: err}
+			}
+		}()
+	}
+
+	for idx := range finders {
+		jobs <- idx
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results, nil
+}
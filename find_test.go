@@ -0,0 +1,293 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReleaseFilterMatch(t *testing.T) {
+	cases := []struct {
+		name       string
+		filter     ReleaseFilter
+		prerelease bool
+		draft      bool
+		want       bool
+	}{
+		{"default excludes draft", ReleaseFilter{}, false, true, false},
+		{"default excludes prerelease", ReleaseFilter{}, true, false, false},
+		{"default allows stable", ReleaseFilter{}, false, false, true},
+		{"IncludeDraft allows draft", ReleaseFilter{IncludeDraft: true}, false, true, true},
+		{"IncludePrerelease allows prerelease", ReleaseFilter{IncludePrerelease: true}, true, false, true},
+		{"StableOnly wins over IncludeDraft", ReleaseFilter{IncludeDraft: true, StableOnly: true}, false, true, false},
+		{"StableOnly wins over IncludePrerelease", ReleaseFilter{IncludePrerelease: true, StableOnly: true}, true, false, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.filter.Match(c.prerelease, c.draft); got != c.want {
+				t.Errorf("Match(%v, %v) = %v, want %v", c.prerelease, c.draft, got, c.want)
+			}
+		})
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"2.0.0", "1.9.9", 1},
+		{"1.0.0", "1.0.0-rc1", 1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+	}
+
+	for _, c := range cases {
+		av, ok := parseSemver(c.a)
+		if !ok {
+			t.Fatalf("parseSemver(%q) failed", c.a)
+		}
+		bv, ok := parseSemver(c.b)
+		if !ok {
+			t.Fatalf("parseSemver(%q) failed", c.b)
+		}
+
+		cmp := compareSemver(av, bv)
+		switch {
+		case c.want == 0 && cmp != 0:
+			t.Errorf("compareSemver(%q, %q) = %d, want 0", c.a, c.b, cmp)
+		case c.want < 0 && cmp >= 0:
+			t.Errorf("compareSemver(%q, %q) = %d, want negative", c.a, c.b, cmp)
+		case c.want > 0 && cmp <= 0:
+			t.Errorf("compareSemver(%q, %q) = %d, want positive", c.a, c.b, cmp)
+		}
+	}
+}
+
+func TestParseSemverConstraints(t *testing.T) {
+	cases := []struct {
+		expr       string
+		semverMode bool
+		matches    string // version that should satisfy the constraints, if semverMode
+		rejects    string // version that should not, if semverMode
+	}{
+		{"^1.2.0", true, "1.9.0", "2.0.0"},
+		{"~1.2.0", true, "1.2.9", "1.3.0"},
+		{">=1.4.0 <2", true, "1.4.0", "2.0.0"},
+		{"=1.2.3", true, "1.2.3", "1.2.4"},
+		{"1.2", false, "", ""}, // bare version: not a constraint expression
+		{"latest", false, "", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			constraints, ok := parseSemverConstraints(c.expr)
+			if ok != c.semverMode {
+				t.Fatalf("parseSemverConstraints(%q) ok = %v, want %v", c.expr, ok, c.semverMode)
+			}
+			if !ok {
+				return
+			}
+
+			if c.matches != "" {
+				v, ok := parseSemver(c.matches)
+				if !ok || !constraints.match(v) {
+					t.Errorf("expected %q to satisfy %q", c.matches, c.expr)
+				}
+			}
+			if c.rejects != "" {
+				v, ok := parseSemver(c.rejects)
+				if !ok || constraints.match(v) {
+					t.Errorf("expected %q to not satisfy %q", c.rejects, c.expr)
+				}
+			}
+		})
+	}
+}
+
+// fetchJSON sends its request to reqUrl and uses host only to pick a token
+// and a cache path, so we can point it straight at an httptest server
+// without needing TLS.
+func TestFetchJSONConditionalRequest(t *testing.T) {
+	const etag = `"abc123"`
+	hits := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+
+		if r.Header.Get("User-Agent") == "" {
+			t.Errorf("request %d: missing User-Agent header", hits)
+		}
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache := &Cache{}
+	newErr := func(resp *http.Response, body []byte, url string) error {
+		t.Fatalf("unexpected error response: %s", resp.Status)
+		return nil
+	}
+
+	body, err := fetchJSON(srv.URL, "example.test", "application/json", "owner/repo", "v1", "", nil, cache, newErr)
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("first fetch body = %s", body)
+	}
+
+	body, err = fetchJSON(srv.URL, "example.test", "application/json", "owner/repo", "v1", "", nil, cache, newErr)
+	if err != nil {
+		t.Fatalf("second fetch: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("second fetch body (from 304) = %s", body)
+	}
+	if hits != 2 {
+		t.Fatalf("expected 2 requests to the server, got %d", hits)
+	}
+}
+
+// giteaAssetJSON renders a minimal release matching GiteaRelease's shape.
+func giteaAssetJSON(tag string, prerelease, draft bool, assets ...string) []byte {
+	type asset struct {
+		DownloadURL string `json:"browser_download_url"`
+	}
+	type release struct {
+		Assets     []asset   `json:"assets"`
+		Prerelease bool      `json:"prerelease"`
+		Draft      bool      `json:"draft"`
+		Tag        string    `json:"tag_name"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	r := release{Tag: tag, Prerelease: prerelease, Draft: draft, CreatedAt: time.Now()}
+	for _, a := range assets {
+		r.Assets = append(r.Assets, asset{DownloadURL: a})
+	}
+
+	body, _ := json.Marshal(r)
+	return body
+}
+
+// withTestClient points http.DefaultClient at client for the duration of the
+// test, restoring it afterwards. fetchJSON has no client injection of its
+// own, so exercising a Finder against an httptest.Server requires this.
+func withTestClient(t *testing.T, client *http.Client) {
+	t.Helper()
+	orig := http.DefaultClient
+	http.DefaultClient = client
+	t.Cleanup(func() { http.DefaultClient = orig })
+}
+
+func TestGiteaAssetFinderFind(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/owner/repo/releases/tags/v1.0.0" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(giteaAssetJSON("v1.0.0", false, false, "https://example.test/asset.tar.gz"))
+	}))
+	defer srv.Close()
+	withTestClient(t, srv.Client())
+
+	finder := &GiteaAssetFinder{
+		Host:  strings.TrimPrefix(srv.URL, "https://"),
+		Repo:  "owner/repo",
+		Tag:   "tags/v1.0.0",
+		Cache: &Cache{Disabled: true},
+	}
+
+	assets, err := finder.Find()
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(assets) != 1 || assets[0] != "https://example.test/asset.tar.gz" {
+		t.Fatalf("Find() assets = %v", assets)
+	}
+}
+
+func TestGiteaAssetFinderFindMatchSubstring(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/releases/tags/v2"):
+			http.NotFound(w, r)
+		case strings.HasSuffix(r.URL.Path, "/releases") && r.URL.Query().Get("page") == "1":
+			w.Write([]byte("[" + string(giteaAssetJSON("v2.1.0", false, false, "https://example.test/v2.1.0.tar.gz")) + "]"))
+		case strings.HasSuffix(r.URL.Path, "/releases"):
+			w.Write([]byte("[]"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	withTestClient(t, srv.Client())
+
+	finder := &GiteaAssetFinder{
+		Host:  strings.TrimPrefix(srv.URL, "https://"),
+		Repo:  "owner/repo",
+		Tag:   "tags/v2",
+		Cache: &Cache{Disabled: true},
+	}
+
+	assets, err := finder.Find()
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(assets) != 1 || assets[0] != "https://example.test/v2.1.0.tar.gz" {
+		t.Fatalf("Find() assets = %v", assets)
+	}
+}
+
+func TestGiteaAssetFinderFindMatchSemver(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/releases/tags/"):
+			http.NotFound(w, r)
+		case strings.HasSuffix(r.URL.Path, "/releases") && r.URL.Query().Get("page") == "1":
+			releases := []json.RawMessage{
+				giteaAssetJSON("v1.2.0", false, false, "https://example.test/1.2.0.tar.gz"),
+				giteaAssetJSON("v1.5.0", false, false, "https://example.test/1.5.0.tar.gz"),
+				giteaAssetJSON("v2.0.0", false, false, "https://example.test/2.0.0.tar.gz"),
+			}
+			body, _ := json.Marshal(releases)
+			w.Write(body)
+		case strings.HasSuffix(r.URL.Path, "/releases"):
+			w.Write([]byte("[]"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	withTestClient(t, srv.Client())
+
+	finder := &GiteaAssetFinder{
+		Host:  strings.TrimPrefix(srv.URL, "https://"),
+		Repo:  "owner/repo",
+		Tag:   "tags/^1.0.0",
+		Cache: &Cache{Disabled: true},
+	}
+
+	assets, err := finder.Find()
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if len(assets) != 1 || assets[0] != "https://example.test/1.5.0.tar.gz" {
+		t.Fatalf("Find() assets = %v, want best match within ^1.0.0 (1.5.0)", assets)
+	}
+}